@@ -0,0 +1,106 @@
+package nosql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// fakeDB is a minimal Database that does not implement BatchInserter, so that
+// BatchInsert/BatchInsertWithConfig always exercise the seqInsert fallback.
+// Insert deterministically fails for every 3rd document (by content, not call
+// order, since concurrent flushing does not preserve call order), to exercise
+// the Failed() path.
+type fakeDB struct {
+	inserts int32 // number of Insert calls observed so far, for the Close test
+}
+
+func (db *fakeDB) Insert(ctx context.Context, col string, key Key, d Document) (Key, error) {
+	atomic.AddInt32(&db.inserts, 1)
+	if i, ok := d["i"].(Int); ok && i%3 == 2 {
+		return nil, fmt.Errorf("fake insert error for %v", key)
+	}
+	return key, nil
+}
+
+func (db *fakeDB) FindByKey(ctx context.Context, col string, key Key) (Document, error) {
+	return nil, ErrNotFound
+}
+func (db *fakeDB) Query(col string) Query            { panic("not implemented") }
+func (db *fakeDB) Update(col string, key Key) Update { panic("not implemented") }
+func (db *fakeDB) Delete(col string) Delete          { panic("not implemented") }
+func (db *fakeDB) EnsureIndex(ctx context.Context, col string, primary Index, secondary []Index) error {
+	return nil
+}
+func (db *fakeDB) Close() error { return nil }
+
+func TestSeqInsertPreservesOrderAndReportsFailures(t *testing.T) {
+	db := &fakeDB{}
+	w := BatchInsertWithConfig(db, "col", BatchConfig{BatchSize: 4, Workers: 4})
+
+	const total = 20
+	var want []Key
+	for i := 0; i < total; i++ {
+		key := Key{fmt.Sprintf("k%02d", i)}
+		if i%3 != 2 { // matches fakeDB's failure pattern (by the "i" field)
+			want = append(want, key)
+		}
+		if err := w.WriteDoc(context.Background(), key, Document{"i": Int(i)}); err != nil {
+			t.Fatalf("WriteDoc(%d): %v", i, err)
+		}
+	}
+	if err := w.Flush(context.Background()); err == nil {
+		t.Fatal("expected Flush to report the failed inserts")
+	}
+
+	got := w.Keys()
+	if len(got) != len(want) {
+		t.Fatalf("Keys() = %v, want %v", got, want)
+	}
+	for i, k := range got {
+		if k[0] != want[i][0] {
+			t.Fatalf("Keys()[%d] = %v, want %v (order not preserved)", i, k, want[i])
+		}
+	}
+
+	if failed := w.Failed(); len(failed) != total-len(want) {
+		t.Fatalf("Failed() = %d entries, want %d", len(failed), total-len(want))
+	}
+}
+
+func TestSeqInsertCloseDiscardsUnflushedDocs(t *testing.T) {
+	db := &fakeDB{}
+	w := BatchInsertWithConfig(db, "col", BatchConfig{BatchSize: 100, Workers: 2})
+
+	if err := w.WriteDoc(context.Background(), Key{"a"}, Document{}); err != nil {
+		t.Fatalf("WriteDoc: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if atomic.LoadInt32(&db.inserts) != 0 {
+		t.Fatalf("Close flushed %d buffered documents, want 0 (they should be discarded)", db.inserts)
+	}
+	if keys := w.Keys(); len(keys) != 0 {
+		t.Fatalf("Keys() = %v after Close, want none", keys)
+	}
+}
+
+func TestSeqInsertConcurrentWriteDocIsRaceFree(t *testing.T) {
+	db := &fakeDB{}
+	w := BatchInsertWithConfig(db, "col", BatchConfig{BatchSize: 8, Workers: 8})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = w.WriteDoc(context.Background(), Key{fmt.Sprintf("k%d", i)}, Document{})
+		}()
+	}
+	wg.Wait()
+	_ = w.Flush(context.Background())
+}
@@ -0,0 +1,299 @@
+package nosql
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// memDB is a tiny in-memory Database used to exercise the optional-interface
+// fallbacks in this package end-to-end. It deliberately implements none of
+// the optional interfaces with an in-package emulation (BulkUpdater,
+// LimitedDeleter, Orderable, FulltextSearcher, RangeScanner) so that tests
+// against it always drive those emulations. It does implement Transactional,
+// through memTx in tx_test.go.
+type memDB struct {
+	mu   sync.Mutex
+	cols map[string][]memDoc
+}
+
+type memDoc struct {
+	key Key
+	doc Document
+}
+
+func newMemDB() *memDB {
+	return &memDB{cols: make(map[string][]memDoc)}
+}
+
+func cloneDoc(d Document) Document {
+	out := make(Document, len(d))
+	for k, v := range d {
+		out[k] = v
+	}
+	return out
+}
+
+func keyEqual(a, b Key) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (db *memDB) Insert(ctx context.Context, col string, key Key, d Document) (Key, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if key == nil {
+		key = GenKey()
+	}
+	db.cols[col] = append(db.cols[col], memDoc{key: key, doc: cloneDoc(d)})
+	return key, nil
+}
+
+func (db *memDB) FindByKey(ctx context.Context, col string, key Key) (Document, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, md := range db.cols[col] {
+		if keyEqual(md.key, key) {
+			return cloneDoc(md.doc), nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (db *memDB) Query(col string) Query {
+	return &memQuery{db: db, col: col}
+}
+
+func (db *memDB) Update(col string, key Key) Update {
+	return &memUpdate{db: db, col: col, key: key}
+}
+
+func (db *memDB) Delete(col string) Delete {
+	return &memDelete{db: db, col: col}
+}
+
+func (db *memDB) EnsureIndex(ctx context.Context, col string, primary Index, secondary []Index) error {
+	return nil
+}
+
+func (db *memDB) Close() error { return nil }
+
+// matchFilters applies filters against doc using the comparisons in FilterOp.
+// It supports single-field paths only, which is all memDB needs for tests.
+func matchFilters(doc Document, filters []FieldFilter) bool {
+	for _, f := range filters {
+		if len(f.Path) != 1 {
+			return false
+		}
+		v := doc[f.Path[0]]
+		switch f.Filter {
+		case Equal:
+			if compareValues(v, f.Value) != 0 {
+				return false
+			}
+		case NotEqual:
+			if compareValues(v, f.Value) == 0 {
+				return false
+			}
+		case GT:
+			if compareValues(v, f.Value) <= 0 {
+				return false
+			}
+		case GTE:
+			if compareValues(v, f.Value) < 0 {
+				return false
+			}
+		case LT:
+			if compareValues(v, f.Value) >= 0 {
+				return false
+			}
+		case LTE:
+			if compareValues(v, f.Value) > 0 {
+				return false
+			}
+		case Regexp:
+			s, ok := v.(String)
+			pat, ok2 := f.Value.(String)
+			if !ok || !ok2 {
+				return false
+			}
+			re, err := regexp.Compile(string(pat))
+			if err != nil || !re.MatchString(string(s)) {
+				return false
+			}
+		case Prefix:
+			s, ok := v.(String)
+			pre, ok2 := f.Value.(String)
+			if !ok || !ok2 || !strings.HasPrefix(string(s), string(pre)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+type memQuery struct {
+	db      *memDB
+	col     string
+	filters []FieldFilter
+	limit   int
+}
+
+func (q *memQuery) WithFields(filters ...FieldFilter) Query {
+	q2 := *q
+	q2.filters = append(append([]FieldFilter{}, q.filters...), filters...)
+	return &q2
+}
+
+func (q *memQuery) Limit(n int) Query {
+	q2 := *q
+	q2.limit = n
+	return &q2
+}
+
+func (q *memQuery) match() []memDoc {
+	q.db.mu.Lock()
+	defer q.db.mu.Unlock()
+	var out []memDoc
+	for _, md := range q.db.cols[q.col] {
+		if matchFilters(md.doc, q.filters) {
+			out = append(out, memDoc{key: md.key, doc: cloneDoc(md.doc)})
+		}
+	}
+	if q.limit > 0 && len(out) > q.limit {
+		out = out[:q.limit]
+	}
+	return out
+}
+
+func (q *memQuery) Count(ctx context.Context) (int64, error) {
+	return int64(len(q.match())), nil
+}
+
+func (q *memQuery) One(ctx context.Context) (Document, error) {
+	docs := q.match()
+	if len(docs) == 0 {
+		return nil, ErrNotFound
+	}
+	return docs[0].doc, nil
+}
+
+func (q *memQuery) Iterate() DocIterator {
+	return &memIter{docs: q.match(), pos: -1}
+}
+
+type memIter struct {
+	docs []memDoc
+	pos  int
+}
+
+func (it *memIter) Next(ctx context.Context) bool {
+	it.pos++
+	return it.pos < len(it.docs)
+}
+
+func (it *memIter) Err() error    { return nil }
+func (it *memIter) Close() error  { return nil }
+func (it *memIter) Key() Key      { return it.docs[it.pos].key }
+func (it *memIter) Doc() Document { return it.docs[it.pos].doc }
+
+type memUpdate struct {
+	db   *memDB
+	col  string
+	key  Key
+	incs []incOp
+	ups  Document
+}
+
+func (u *memUpdate) Inc(field string, dn int) Update {
+	u2 := *u
+	u2.incs = append(append([]incOp{}, u.incs...), incOp{field, dn})
+	return &u2
+}
+
+func (u *memUpdate) Upsert(d Document) Update {
+	u2 := *u
+	u2.ups = d
+	return &u2
+}
+
+func (u *memUpdate) Do(ctx context.Context) error {
+	u.db.mu.Lock()
+	defer u.db.mu.Unlock()
+	list := u.db.cols[u.col]
+	for i, md := range list {
+		if !keyEqual(md.key, u.key) {
+			continue
+		}
+		for _, inc := range u.incs {
+			cur, _ := md.doc[inc.field].(Int)
+			md.doc[inc.field] = cur + Int(inc.dn)
+		}
+		list[i] = md
+		return nil
+	}
+	doc := cloneDoc(u.ups)
+	if doc == nil {
+		doc = Document{}
+	}
+	for _, inc := range u.incs {
+		doc[inc.field] = Int(inc.dn)
+	}
+	u.db.cols[u.col] = append(list, memDoc{key: u.key, doc: doc})
+	return nil
+}
+
+type memDelete struct {
+	db      *memDB
+	col     string
+	filters []FieldFilter
+	keys    []Key
+}
+
+func (d *memDelete) WithFields(filters ...FieldFilter) Delete {
+	d2 := *d
+	d2.filters = append(append([]FieldFilter{}, d.filters...), filters...)
+	return &d2
+}
+
+func (d *memDelete) Keys(keys ...Key) Delete {
+	d2 := *d
+	d2.keys = append(append([]Key{}, d.keys...), keys...)
+	return &d2
+}
+
+func (d *memDelete) matches(md memDoc) bool {
+	if !matchFilters(md.doc, d.filters) {
+		return false
+	}
+	if len(d.keys) == 0 {
+		return true
+	}
+	for _, k := range d.keys {
+		if keyEqual(k, md.key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *memDelete) Do(ctx context.Context) error {
+	d.db.mu.Lock()
+	defer d.db.mu.Unlock()
+	var keep []memDoc
+	for _, md := range d.db.cols[d.col] {
+		if !d.matches(md) {
+			keep = append(keep, md)
+		}
+	}
+	d.db.cols[d.col] = keep
+	return nil
+}
@@ -3,12 +3,21 @@ package nosql
 import (
 	"context"
 	"errors"
-	"github.com/pborman/uuid"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/pborman/uuid"
 )
 
 var (
 	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned by Tx.Commit when a transaction could not be applied
+	// due to a concurrent modification of one of the documents it touched.
+	ErrConflict = errors.New("nosql: transaction conflict")
 )
 
 // Key is a set of values that describe primary key of document.
@@ -56,6 +65,38 @@ type Database interface {
 	Close() error
 }
 
+// Transactional is an optional interface for databases that can group mutations
+// across multiple documents and collections into a single atomic unit.
+//
+// Implementations that natively support transactions should map Tx directly onto them.
+// Others may implement it via optimistic concurrency control using a version field.
+type Transactional interface {
+	// Tx starts a new transaction. The returned Tx must be finished with
+	// either Commit or Rollback.
+	Tx(ctx context.Context) (Tx, error)
+}
+
+// Tx is a set of mutations that will be applied atomically once Commit is called.
+type Tx interface {
+	// Insert creates a document with a given key in a given collection.
+	// Key can be nil meaning that implementation should generate a unique key for the item.
+	Insert(ctx context.Context, col string, key Key, d Document) (Key, error)
+	// Update starts construction of document update request for a specified document and collection.
+	Update(col string, key Key) Update
+	// Delete starts construction of document delete request.
+	Delete(col string) Delete
+	// FindByKey finds a document by it's Key. It returns ErrNotFound if document not exists.
+	// Reads observe the transaction's own uncommitted writes.
+	FindByKey(ctx context.Context, col string, key Key) (Document, error)
+	// Query starts construction of a new query for a specified collection, scoped to this transaction.
+	Query(col string) Query
+	// Commit applies all mutations made so far atomically.
+	// It returns ErrConflict if the transaction could not be applied due to a concurrent modification.
+	Commit(ctx context.Context) error
+	// Rollback discards all mutations made so far.
+	Rollback(ctx context.Context) error
+}
+
 // FilterOp is a comparison operation type used for value filters.
 type FilterOp int
 
@@ -66,6 +107,8 @@ const (
 	GTE
 	LT
 	LTE
+	Regexp // Value must be a String holding a regular expression
+	Prefix // Value must be a String holding a literal prefix
 )
 
 // FieldFilter represents a single field comparison operation.
@@ -90,6 +133,236 @@ type Query interface {
 	Iterate() DocIterator
 }
 
+// FulltextSearcher is an optional interface for Query implementations that can
+// push a full-text search down to a native StringFulltext index. Drivers that
+// don't implement it get a regex-scan emulation through Search.
+type FulltextSearcher interface {
+	Query
+	// Search adds a full-text search condition on field, matching documents against terms.
+	Search(field string, terms string) Query
+}
+
+// Search adds a full-text search condition on field, matching documents
+// against terms. It uses q's native StringFulltext support if q implements
+// FulltextSearcher, otherwise falls back to a case-insensitive regex scan
+// over field via WithFields.
+func Search(q Query, field string, terms string) Query {
+	if fs, ok := q.(FulltextSearcher); ok {
+		return fs.Search(field, terms)
+	}
+	return q.WithFields(FieldFilter{
+		Path:   []string{field},
+		Filter: Regexp,
+		Value:  String("(?i)" + regexp.QuoteMeta(terms)),
+	})
+}
+
+// Orderable is an optional interface for Query implementations that can sort,
+// skip and resume iteration natively, e.g. via an ordered secondary index
+// (see IntIndex, FloatIndex, TimeIndex). Drivers that don't implement it get
+// an in-memory emulation through Paginate.
+type Orderable interface {
+	Query
+	// OrderBy sorts results by field, ascending if asc is true, descending otherwise.
+	OrderBy(field string, asc bool) Orderable
+	// Skip omits the first n documents that would otherwise be returned.
+	// Skip and IterateFrom are mutually exclusive: IterateFrom resumes from
+	// a cursor on its own, so it resets any skip set on the Orderable it is
+	// called on rather than adding to it.
+	Skip(n int) Orderable
+	// IterateFrom resumes iteration over query results starting right after
+	// the document that produced cursor, as returned by CursorIterator.Cursor.
+	IterateFrom(cursor []byte) DocIterator
+}
+
+// Paginate returns q as an Orderable. If q doesn't implement Orderable
+// natively, it is wrapped in an in-memory sort/skip/cursor emulation, the
+// same way BatchInsert falls back to seqInsert for drivers without
+// BatchInserter.
+func Paginate(q Query) Orderable {
+	if o, ok := q.(Orderable); ok {
+		return o
+	}
+	return &pagedQuery{Query: q}
+}
+
+// pagedQuery emulates Orderable over an arbitrary Query by buffering all of
+// its results, then sorting, skipping and slicing them in memory.
+type pagedQuery struct {
+	Query
+	field string
+	asc   bool
+	skip  int
+}
+
+func (q *pagedQuery) OrderBy(field string, asc bool) Orderable {
+	q2 := *q
+	q2.field, q2.asc = field, asc
+	return &q2
+}
+
+func (q *pagedQuery) Skip(n int) Orderable {
+	q2 := *q
+	q2.skip = n
+	return &q2
+}
+
+func (q *pagedQuery) Iterate() DocIterator {
+	return &sortSkipIter{inner: q.Query.Iterate(), field: q.field, asc: q.asc, skip: q.skip, pos: -1}
+}
+
+// IterateFrom resumes from cursor instead of from q's own skip: the two are
+// mutually exclusive, since a cursor already encodes how many documents were
+// consumed (including any that were skipped to produce it).
+func (q *pagedQuery) IterateFrom(cursor []byte) DocIterator {
+	it := &sortSkipIter{inner: q.Query.Iterate(), field: q.field, asc: q.asc, pos: -1}
+	it.resumeAt = decodeOffset(cursor)
+	return it
+}
+
+// sortSkipIter wraps an inner DocIterator, draining it in full on the first
+// call to Next so that results can be sorted and skipped in memory. Cursor
+// reports the number of documents already consumed, which IterateFrom
+// decodes back into a resume offset.
+type sortSkipIter struct {
+	inner    DocIterator
+	field    string
+	asc      bool
+	skip     int
+	resumeAt int
+
+	loaded bool
+	err    error
+	docs   []Document
+	keys   []Key
+	pos    int
+}
+
+func (it *sortSkipIter) load(ctx context.Context) {
+	if it.loaded {
+		return
+	}
+	it.loaded = true
+	for it.inner.Next(ctx) {
+		it.docs = append(it.docs, it.inner.Doc())
+		it.keys = append(it.keys, it.inner.Key())
+	}
+	it.err = it.inner.Err()
+	if it.field != "" {
+		// Sort a permutation, so that keys stay paired with their documents.
+		perm := make([]int, len(it.docs))
+		for i := range perm {
+			perm[i] = i
+		}
+		sort.SliceStable(perm, func(i, j int) bool {
+			less := compareValues(it.docs[perm[i]][it.field], it.docs[perm[j]][it.field]) < 0
+			if !it.asc {
+				return !less
+			}
+			return less
+		})
+		docs, keys := make([]Document, len(perm)), make([]Key, len(perm))
+		for i, p := range perm {
+			docs[i], keys[i] = it.docs[p], it.keys[p]
+		}
+		it.docs, it.keys = docs, keys
+	}
+	off := it.skip + it.resumeAt
+	if off > len(it.docs) {
+		off = len(it.docs)
+	}
+	it.docs = it.docs[off:]
+	it.keys = it.keys[off:]
+}
+
+func (it *sortSkipIter) Next(ctx context.Context) bool {
+	it.load(ctx)
+	if it.err != nil {
+		return false
+	}
+	it.pos++
+	return it.pos < len(it.docs)
+}
+
+func (it *sortSkipIter) Err() error { return it.err }
+
+func (it *sortSkipIter) Close() error { return it.inner.Close() }
+
+func (it *sortSkipIter) Key() Key { return it.keys[it.pos] }
+
+func (it *sortSkipIter) Doc() Document { return it.docs[it.pos] }
+
+// Cursor returns the total number of documents consumed so far (including
+// ones skipped), so that IterateFrom can resume right after them.
+func (it *sortSkipIter) Cursor() []byte {
+	return encodeOffset(it.skip + it.resumeAt + it.pos + 1)
+}
+
+func encodeOffset(n int) []byte {
+	return strconv.AppendInt(nil, int64(n), 10)
+}
+
+func decodeOffset(cursor []byte) int {
+	if len(cursor) == 0 {
+		return 0
+	}
+	n, err := strconv.ParseInt(string(cursor), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return int(n)
+}
+
+// compareValues orders two Values for use by the in-memory OrderBy emulation.
+// It supports the comparable scalar Value types; any other combination
+// (including incomparable or mismatched types) is treated as equal.
+func compareValues(a, b Value) int {
+	switch x := a.(type) {
+	case String:
+		if y, ok := b.(String); ok {
+			return strings.Compare(string(x), string(y))
+		}
+	case Int:
+		if y, ok := b.(Int); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+		}
+	case Float:
+		if y, ok := b.(Float); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+		}
+	case Time:
+		if y, ok := b.(Time); ok {
+			switch {
+			case time.Time(x).Before(time.Time(y)):
+				return -1
+			case time.Time(x).After(time.Time(y)):
+				return 1
+			}
+		}
+	}
+	return 0
+}
+
+// CursorIterator is an optional interface for DocIterator implementations
+// that can report a resumable position via Cursor. The in-memory Orderable
+// fallback returned by Paginate always implements it.
+type CursorIterator interface {
+	DocIterator
+	// Cursor returns an opaque token identifying the current document's
+	// position, suitable for resuming iteration later via Orderable.IterateFrom.
+	Cursor() []byte
+}
+
 // Update is an update request builder.
 type Update interface {
 	// Inc increments document field with a given amount. Will also increment upserted document.
@@ -112,6 +385,196 @@ type Delete interface {
 	Do(ctx context.Context) error
 }
 
+// LimitedDeleter is an optional interface for Delete builders that can cap the
+// number of matching documents removed natively, e.g. by pushing the limit
+// down into a ranged/prefix delete. Drivers that don't implement it get a
+// find-then-delete-by-key emulation through DeleteLimit.
+type LimitedDeleter interface {
+	Delete
+	// Limit caps the number of matching documents that will be removed.
+	// A limit of 0 (the default) means no limit.
+	Limit(n int) Delete
+}
+
+// DeleteLimit caps del, a Delete builder for col on db, to remove at most n
+// matching documents. It uses del's native LimitedDeleter support when
+// available, otherwise falls back to finding the first n matching keys via a
+// Query against col and deleting exactly those keys.
+func DeleteLimit(db Database, col string, del Delete, n int) Delete {
+	if ld, ok := del.(LimitedDeleter); ok {
+		return ld.Limit(n)
+	}
+	return &limitDelete{db: db, col: col, Delete: del, n: n}
+}
+
+// limitDelete emulates LimitedDeleter for any Delete by tracking the filters
+// and keys applied to it, then running a Limit(n) Query for the matching
+// keys at Do time and deleting exactly those.
+type limitDelete struct {
+	Delete
+	db      Database
+	col     string
+	n       int
+	filters []FieldFilter
+	keys    []Key
+}
+
+func (d *limitDelete) WithFields(filters ...FieldFilter) Delete {
+	d2 := *d
+	d2.filters = append(append([]FieldFilter{}, d.filters...), filters...)
+	d2.Delete = d.Delete.WithFields(filters...)
+	return &d2
+}
+
+func (d *limitDelete) Keys(keys ...Key) Delete {
+	d2 := *d
+	d2.keys = append(append([]Key{}, d.keys...), keys...)
+	d2.Delete = d.Delete.Keys(keys...)
+	return &d2
+}
+
+func (d *limitDelete) Do(ctx context.Context) error {
+	q := d.db.Query(d.col).WithFields(d.filters...)
+	if len(d.keys) == 0 {
+		q = q.Limit(d.n)
+	}
+	it := q.Iterate()
+	defer it.Close()
+
+	allowed := make(map[string]bool, len(d.keys))
+	for _, k := range d.keys {
+		allowed[strings.Join(k, "\x00")] = true
+	}
+	var match []Key
+	for it.Next(ctx) {
+		k := it.Key()
+		if len(allowed) > 0 && !allowed[strings.Join(k, "\x00")] {
+			continue
+		}
+		match = append(match, k)
+		if d.n > 0 && len(match) == d.n {
+			break
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	if len(match) == 0 {
+		return nil
+	}
+	return d.db.Delete(d.col).Keys(match...).Do(ctx)
+}
+
+// BulkUpdate is a builder for updates that apply to every document matching a filter.
+type BulkUpdate interface {
+	// WithFields adds specified filters to select documents to update.
+	WithFields(filters ...FieldFilter) BulkUpdate
+	// Inc increments a field of every matching document by a given amount.
+	Inc(field string, dn int) BulkUpdate
+	// Set overwrites a field of every matching document with a given value.
+	Set(field string, v Value) BulkUpdate
+	// Do executes the bulk update and returns the number of documents modified.
+	Do(ctx context.Context) (int64, error)
+}
+
+// BulkUpdater is an optional interface for databases that can apply an update
+// to every matching document without a read-modify-write loop, e.g. Mongo's
+// updateMany or a SQL UPDATE ... WHERE. Drivers that don't implement it get a
+// read-modify-write emulation through UpdateAll.
+type BulkUpdater interface {
+	Database
+	// UpdateAll starts construction of a bulk update request that applies to
+	// every document in the collection matching the builder's filters.
+	UpdateAll(col string) BulkUpdate
+}
+
+// UpdateAll returns a BulkUpdate builder for col. It uses db's native
+// BulkUpdater support when available, otherwise falls back to a
+// read-modify-write loop: querying for matching documents and applying Inc
+// (via Update) and Set (via delete-and-reinsert, since Update.Upsert only
+// takes effect on documents that don't already exist) to each one found.
+func UpdateAll(db Database, col string) BulkUpdate {
+	if bu, ok := db.(BulkUpdater); ok {
+		return bu.UpdateAll(col)
+	}
+	return &rmwBulkUpdate{db: db, col: col}
+}
+
+type incOp struct {
+	field string
+	dn    int
+}
+
+type setOp struct {
+	field string
+	v     Value
+}
+
+// rmwBulkUpdate emulates BulkUpdater over any Database by running a Query for
+// the matching filters and applying Inc/Set to each document found.
+type rmwBulkUpdate struct {
+	db      Database
+	col     string
+	filters []FieldFilter
+	incs    []incOp
+	sets    []setOp
+}
+
+func (u *rmwBulkUpdate) WithFields(filters ...FieldFilter) BulkUpdate {
+	u.filters = append(u.filters, filters...)
+	return u
+}
+
+func (u *rmwBulkUpdate) Inc(field string, dn int) BulkUpdate {
+	u.incs = append(u.incs, incOp{field, dn})
+	return u
+}
+
+func (u *rmwBulkUpdate) Set(field string, v Value) BulkUpdate {
+	u.sets = append(u.sets, setOp{field, v})
+	return u
+}
+
+func (u *rmwBulkUpdate) Do(ctx context.Context) (int64, error) {
+	it := u.db.Query(u.col).WithFields(u.filters...).Iterate()
+	defer it.Close()
+
+	var n int64
+	for it.Next(ctx) {
+		key := it.Key()
+		if len(u.sets) > 0 {
+			// Update.Upsert only takes effect on documents that don't already
+			// exist, so assigning a field unconditionally on a document the
+			// Query just found means replacing it outright: delete the old
+			// document and reinsert it with the new field values merged in.
+			doc := it.Doc()
+			if doc == nil {
+				doc = make(Document, len(u.sets))
+			}
+			for _, s := range u.sets {
+				doc[s.field] = s.v
+			}
+			if err := u.db.Delete(u.col).Keys(key).Do(ctx); err != nil {
+				return n, err
+			}
+			if _, err := u.db.Insert(ctx, u.col, key, doc); err != nil {
+				return n, err
+			}
+		}
+		if len(u.incs) > 0 {
+			upd := u.db.Update(u.col, key)
+			for _, inc := range u.incs {
+				upd = upd.Inc(inc.field, inc.dn)
+			}
+			if err := upd.Do(ctx); err != nil {
+				return n, err
+			}
+		}
+		n++
+	}
+	return n, it.Err()
+}
+
 // DocIterator is an iterator over a list of documents.
 type DocIterator interface {
 	// Next advances an iterator to the next document.
@@ -126,41 +589,182 @@ type DocIterator interface {
 	Doc() Document
 }
 
+// BatchConfig controls how the emulated batch writer returned by BatchInsert
+// buffers and flushes documents for drivers that don't implement BatchInserter.
+type BatchConfig struct {
+	// BatchSize caps the number of documents buffered before an automatic flush.
+	// 0 uses defaultBatchSize.
+	BatchSize int
+	// BatchBytes caps the approximate buffered size, in bytes, before an automatic
+	// flush. 0 means no byte-based limit.
+	BatchBytes int
+	// Workers caps the number of concurrent Insert calls used while flushing a
+	// buffered batch. 0 uses defaultBatchWorkers.
+	Workers int
+}
+
+const (
+	defaultBatchSize    = 100
+	defaultBatchWorkers = 4
+)
+
 // BatchInsert returns a streaming writer for database or emulates it if database has no support for batch inserts.
 func BatchInsert(db Database, col string) DocWriter {
+	return BatchInsertWithConfig(db, col, BatchConfig{})
+}
+
+// BatchInsertWithConfig is like BatchInsert, but lets the caller tune the
+// buffering and concurrency of the emulated writer. The config is ignored if
+// db implements BatchInserter.
+func BatchInsertWithConfig(db Database, col string, cfg BatchConfig) DocWriter {
 	if bi, ok := db.(BatchInserter); ok {
 		return bi.BatchInsert(col)
 	}
-	return &seqInsert{db: db, col: col}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultBatchWorkers
+	}
+	return &seqInsert{db: db, col: col, cfg: cfg}
+}
+
+// FailedDoc records a single document that failed to be written by a DocWriter.
+type FailedDoc struct {
+	Key Key
+	Doc Document
+	Err error
+}
+
+// bufDoc is a document buffered for insertion. idx is its position among all
+// documents ever written to the seqInsert, used to report Keys() in the
+// original WriteDoc order despite being flushed concurrently.
+type bufDoc struct {
+	idx int
+	key Key
+	doc Document
 }
 
+// seqInsert buffers up to cfg.BatchSize/BatchBytes documents and flushes them
+// concurrently across cfg.Workers goroutines, instead of inserting one document
+// at a time, for drivers that don't implement BatchInserter. Close discards
+// anything still buffered rather than flushing it, per the DocWriter contract.
 type seqInsert struct {
-	db   Database
-	col  string
-	keys []Key
-	err  error
+	db  Database
+	col string
+	cfg BatchConfig
+
+	mu      sync.Mutex
+	buf     []bufDoc
+	bufLen  int
+	results []Key // results[i] is the key for the i'th WriteDoc call, or nil if it hasn't succeeded (yet)
+	failed  []FailedDoc
 }
 
 func (w *seqInsert) WriteDoc(ctx context.Context, key Key, d Document) error {
-	key, err := w.db.Insert(ctx, w.col, key, d)
-	if err != nil {
-		w.err = err
-		return err
+	w.mu.Lock()
+	idx := len(w.results)
+	w.results = append(w.results, nil)
+	w.buf = append(w.buf, bufDoc{idx: idx, key: key, doc: d})
+	w.bufLen += docSize(d)
+	full := len(w.buf) >= w.cfg.BatchSize || (w.cfg.BatchBytes > 0 && w.bufLen >= w.cfg.BatchBytes)
+	w.mu.Unlock()
+	if full {
+		return w.flushBuffered(ctx)
+	}
+	return nil
+}
+
+func (w *seqInsert) flushBuffered(ctx context.Context) error {
+	w.mu.Lock()
+	buf := w.buf
+	w.buf, w.bufLen = nil, 0
+	w.mu.Unlock()
+	if len(buf) == 0 {
+		return nil
 	}
-	w.keys = append(w.keys, key)
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, w.cfg.Workers)
+	)
+	for _, bd := range buf {
+		bd := bd
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			key, err := w.db.Insert(ctx, w.col, bd.key, bd.doc)
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			if err != nil {
+				w.failed = append(w.failed, FailedDoc{Key: bd.key, Doc: bd.doc, Err: err})
+				return
+			}
+			w.results[bd.idx] = key
+		}()
+	}
+	wg.Wait()
 	return nil
 }
 
+func docSize(d Document) int {
+	n := 0
+	for k, v := range d {
+		n += len(k)
+		switch x := v.(type) {
+		case String:
+			n += len(x)
+		case Bytes:
+			n += len(x)
+		default:
+			n += 8
+		}
+	}
+	return n
+}
+
 func (w *seqInsert) Flush(ctx context.Context) error {
-	return w.err
+	if err := w.flushBuffered(ctx); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.failed) != 0 {
+		return w.failed[len(w.failed)-1].Err
+	}
+	return nil
 }
 
+// Keys returns the keys of documents inserted so far, in the order WriteDoc
+// was called for them. Documents that failed (see Failed) are omitted, and
+// documents still buffered are not reflected until Flush is called.
 func (w *seqInsert) Keys() []Key {
-	return w.keys
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	keys := make([]Key, 0, len(w.results))
+	for _, k := range w.results {
+		if k != nil {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func (w *seqInsert) Failed() []FailedDoc {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]FailedDoc{}, w.failed...)
 }
 
+// Close discards any documents still buffered and have not been flushed yet.
+// Unlike Flush, it never issues further Insert calls.
 func (w *seqInsert) Close() error {
-	return w.err
+	w.mu.Lock()
+	w.buf, w.bufLen = nil, 0
+	w.mu.Unlock()
+	return nil
 }
 
 // DocWriter is an interface for writing documents in streaming manner.
@@ -169,9 +773,12 @@ type DocWriter interface {
 	WriteDoc(ctx context.Context, key Key, d Document) error
 	// Flush waits for all writes to complete.
 	Flush(ctx context.Context) error
-	// Keys returns a list of already inserted documents.
-	// Might be less then a number of written documents until Flush is called.
+	// Keys returns the keys of already inserted documents, in the order they
+	// were passed to WriteDoc. Might be less then a number of written documents
+	// until Flush is called, and omits keys of documents reported by Failed.
 	Keys() []Key
+	// Failed returns documents that could not be written, along with their errors.
+	Failed() []FailedDoc
 	// Close closes writer and discards any unflushed documents.
 	Close() error
 }
@@ -181,20 +788,45 @@ type BatchInserter interface {
 	BatchInsert(col string) DocWriter
 }
 
+// InsertStats summarizes the outcome of a batch insert performed through BatchInserterV2.
+type InsertStats struct {
+	Inserted int
+	Failed   int
+}
+
+// BatchInserterV2 is an optional interface for databases that can insert documents
+// in batches given a context for the whole batch, and report aggregate statistics
+// once writing is done. It supersedes BatchInserter for higher-throughput ingest
+// pipelines such as Cayley's .nq bulk load.
+type BatchInserterV2 interface {
+	// BatchInsert2 starts a streaming writer for col, scoped to ctx.
+	BatchInsert2(ctx context.Context, col string) DocWriterV2
+}
+
+// DocWriterV2 is a DocWriter that also reports statistics about the batch
+// written so far.
+type DocWriterV2 interface {
+	DocWriter
+	// Stats returns counts of documents inserted and failed so far.
+	Stats() InsertStats
+}
+
 // IndexType is a type of index for collection.
 type IndexType int
 
 const (
-	IndexAny    = IndexType(iota)
-	StringExact // exact match for string values (usually a hash index)
-
-	//StringFulltext
-	//IntIndex
-	//FloatIndex
-	//TimeIndex
+	IndexAny       = IndexType(iota)
+	StringExact    // exact match for string values (usually a hash index)
+	StringFulltext // full-text search index for string values, used by the nosql.Search helper
+	IntIndex       // ordered index over Int values, supports range scans
+	FloatIndex     // ordered index over Float values, supports range scans
+	TimeIndex      // ordered index over Time values, supports range scans
 )
 
 // Index is an index for a collection of documents.
+//
+// Fields may name more than one field to describe a composite index,
+// in which case values are compared in the order the fields are listed.
 type Index struct {
 	Fields []string // an ordered set of fields used in index
 	Type   IndexType
@@ -255,4 +887,4 @@ func (Bytes) isValue() {}
 // Strings is an array of strings. Used mostly to store Keys.
 type Strings []string
 
-func (Strings) isValue() {}
\ No newline at end of file
+func (Strings) isValue() {}
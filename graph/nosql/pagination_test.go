@@ -0,0 +1,126 @@
+package nosql
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPaginateFallbackOrdersAndSkips(t *testing.T) {
+	db := newMemDB()
+	ctx := context.Background()
+
+	for i, name := range []string{"charlie", "alice", "echo", "bravo", "delta"} {
+		if _, err := db.Insert(ctx, "docs", nil, Document{"name": String(name), "n": Int(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	q := Paginate(db.Query("docs")).OrderBy("name", true).Skip(1)
+	it := q.Iterate()
+	defer it.Close()
+
+	var got []string
+	for it.Next(ctx) {
+		got = append(got, string(it.Doc()["name"].(String)))
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"bravo", "charlie", "delta", "echo"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestPaginateFallbackCursorResume(t *testing.T) {
+	db := newMemDB()
+	ctx := context.Background()
+
+	for i, name := range []string{"charlie", "alice", "echo", "bravo", "delta"} {
+		if _, err := db.Insert(ctx, "docs", nil, Document{"name": String(name), "n": Int(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	q := Paginate(db.Query("docs")).OrderBy("name", true)
+	it := q.Iterate().(CursorIterator)
+	defer it.Close()
+
+	var page1 []string
+	for i := 0; i < 2 && it.Next(ctx); i++ {
+		page1 = append(page1, string(it.Doc()["name"].(String)))
+	}
+	if want := []string{"alice", "bravo"}; len(page1) != 2 || page1[0] != want[0] || page1[1] != want[1] {
+		t.Fatalf("page1 = %v, want %v", page1, want)
+	}
+	cursor := it.Cursor()
+	it.Close()
+
+	it2 := q.IterateFrom(cursor)
+	defer it2.Close()
+	var page2 []string
+	for it2.Next(ctx) {
+		page2 = append(page2, string(it2.Doc()["name"].(String)))
+	}
+	want2 := []string{"charlie", "delta", "echo"}
+	if len(page2) != len(want2) {
+		t.Fatalf("page2 = %v, want %v", page2, want2)
+	}
+	for i := range want2 {
+		if page2[i] != want2[i] {
+			t.Fatalf("page2 = %v, want %v", page2, want2)
+		}
+	}
+}
+
+// TestPaginateSkipThenIterateFromDoesNotDoubleCount reproduces a bug where
+// Skip(n) followed by IterateFrom(cursor) on the same Orderable added the
+// skip to the cursor's own offset, silently dropping n extra documents.
+// IterateFrom must resume purely from the cursor, ignoring any skip set on
+// the Orderable it's called on.
+func TestPaginateSkipThenIterateFromDoesNotDoubleCount(t *testing.T) {
+	db := newMemDB()
+	ctx := context.Background()
+
+	for i, name := range []string{"charlie", "alice", "echo", "bravo", "delta"} {
+		if _, err := db.Insert(ctx, "docs", nil, Document{"name": String(name), "n": Int(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	q := Paginate(db.Query("docs")).OrderBy("name", true).Skip(1)
+	it := q.Iterate().(CursorIterator)
+	defer it.Close()
+
+	var page1 []string
+	for i := 0; i < 2 && it.Next(ctx); i++ {
+		page1 = append(page1, string(it.Doc()["name"].(String)))
+	}
+	want := []string{"bravo", "charlie"}
+	if len(page1) != 2 || page1[0] != want[0] || page1[1] != want[1] {
+		t.Fatalf("page1 = %v, want %v", page1, want)
+	}
+	cursor := it.Cursor()
+	it.Close()
+
+	it2 := q.IterateFrom(cursor)
+	defer it2.Close()
+	var page2 []string
+	for it2.Next(ctx) {
+		page2 = append(page2, string(it2.Doc()["name"].(String)))
+	}
+	want2 := []string{"delta", "echo"}
+	if len(page2) != len(want2) {
+		t.Fatalf("page2 = %v, want %v (IterateFrom must not re-apply the Orderable's own Skip on top of the cursor)", page2, want2)
+	}
+	for i := range want2 {
+		if page2[i] != want2[i] {
+			t.Fatalf("page2 = %v, want %v", page2, want2)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package nosql
+
+import "context"
+
+// RangeScanner is an optional interface for databases that can execute a range
+// scan directly against a secondary index, instead of filtering a full collection
+// scan. Low and high bound the scan on each of index.Fields, in order; a nil bound
+// on either side means the scan is open-ended in that direction.
+type RangeScanner interface {
+	RangeScan(ctx context.Context, col string, index Index, low, high []Value) DocIterator
+}
+
+// Planner picks the most selective index available for a query's filters and
+// ordering, so that drivers implementing RangeScanner can execute a range scan
+// instead of a full collection scan. It is shared across drivers: the planner
+// only decides which index to use, drivers supply RangeScan itself.
+type Planner struct {
+	indexes []Index
+}
+
+// NewPlanner builds a Planner aware of the given secondary indexes.
+// Callers typically pass the same indexes they registered via EnsureIndex.
+func NewPlanner(indexes []Index) *Planner {
+	return &Planner{indexes: append([]Index{}, indexes...)}
+}
+
+// Plan inspects filters and, optionally, an order-by field, and returns the
+// index that best matches them together with the bounds to scan, or ok == false
+// if no index applies and the caller should fall back to a regular Query.
+func (p *Planner) Plan(filters []FieldFilter, orderBy string) (idx Index, low, high []Value, ok bool) {
+	best := -1
+	bestScore := 0
+	for i, ix := range p.indexes {
+		score := p.score(ix, filters, orderBy)
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best < 0 {
+		return Index{}, nil, nil, false
+	}
+	idx = p.indexes[best]
+	low, high = bounds(idx, filters)
+	return idx, low, high, true
+}
+
+// score rates how well index matches filters and orderBy: one point per leading
+// field covered by an Equal/GT/GTE/LT/LTE filter, plus one point if the index
+// also satisfies the requested ordering. A field covered by an unrelated op
+// (Regexp, Prefix, NotEqual) does not count, since it cannot be used in a range scan.
+func (p *Planner) score(idx Index, filters []FieldFilter, orderBy string) int {
+	score := 0
+	for _, f := range idx.Fields {
+		matched := false
+		for _, flt := range filters {
+			if len(flt.Path) != 1 || flt.Path[0] != f {
+				continue
+			}
+			switch flt.Filter {
+			case Equal, GT, GTE, LT, LTE:
+				matched = true
+			}
+		}
+		if !matched {
+			break
+		}
+		score++
+	}
+	if orderBy != "" && len(idx.Fields) > 0 && idx.Fields[0] == orderBy {
+		score++
+	}
+	return score
+}
+
+// bounds derives the low/high range for idx from filters. Only the leading
+// fields of idx that are covered by an Equal/GT/GTE/LT/LTE filter contribute;
+// the remaining bound positions are left nil (open-ended).
+func bounds(idx Index, filters []FieldFilter) (low, high []Value) {
+	low = make([]Value, len(idx.Fields))
+	high = make([]Value, len(idx.Fields))
+	for i, f := range idx.Fields {
+		covered := false
+		for _, flt := range filters {
+			if len(flt.Path) != 1 || flt.Path[0] != f {
+				continue
+			}
+			switch flt.Filter {
+			case Equal:
+				low[i], high[i] = flt.Value, flt.Value
+				covered = true
+			case GT, GTE:
+				low[i] = flt.Value
+				covered = true
+			case LT, LTE:
+				high[i] = flt.Value
+				covered = true
+			}
+		}
+		if !covered {
+			break
+		}
+	}
+	return low, high
+}
+
+// Scan runs filters (and, if set, orderBy) against col, using p to pick a
+// RangeScanner index when db implements one, the same way BatchInsert falls
+// back to seqInsert for drivers without BatchInserter. If db doesn't
+// implement RangeScanner, or p finds no index worth using, Scan falls back
+// to a plain Query, ordered through Paginate when orderBy is set.
+func Scan(ctx context.Context, db Database, col string, p *Planner, filters []FieldFilter, orderBy string) DocIterator {
+	if rs, ok := db.(RangeScanner); ok && p != nil {
+		if idx, low, high, ok := p.Plan(filters, orderBy); ok {
+			return rs.RangeScan(ctx, col, idx, low, high)
+		}
+	}
+	q := db.Query(col).WithFields(filters...)
+	if orderBy != "" {
+		return Paginate(q).OrderBy(orderBy, true).Iterate()
+	}
+	return q.Iterate()
+}
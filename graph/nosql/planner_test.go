@@ -0,0 +1,116 @@
+package nosql
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPlannerScoreAndBounds(t *testing.T) {
+	idxAB := Index{Fields: []string{"a", "b"}}
+	idxC := Index{Fields: []string{"c"}}
+	p := NewPlanner([]Index{idxAB, idxC})
+
+	filters := []FieldFilter{
+		{Path: []string{"a"}, Filter: Equal, Value: Int(1)},
+		{Path: []string{"b"}, Filter: GTE, Value: Int(5)},
+	}
+	idx, low, high, ok := p.Plan(filters, "")
+	if !ok {
+		t.Fatal("Plan returned ok = false, want an index to match")
+	}
+	if idx.Fields[0] != "a" || idx.Fields[1] != "b" {
+		t.Fatalf("Plan picked index %v, want the (a, b) index", idx)
+	}
+	if low[0] != Int(1) || high[0] != Int(1) {
+		t.Fatalf("bounds on a = [%v, %v], want [1, 1] (Equal pins both)", low[0], high[0])
+	}
+	if low[1] != Int(5) || high[1] != nil {
+		t.Fatalf("bounds on b = [%v, %v], want [5, nil] (GTE is open-ended above)", low[1], high[1])
+	}
+
+	// A Regexp filter can't drive a range scan, so it stops the match at the
+	// first field it's applied to.
+	idx2, _, _, ok2 := p.Plan([]FieldFilter{
+		{Path: []string{"a"}, Filter: Regexp, Value: String("x")},
+	}, "")
+	if ok2 {
+		t.Fatalf("Plan matched index %v on a Regexp filter, want no match", idx2)
+	}
+
+	// orderBy alone, matching the index's leading field, is still worth a point.
+	idx3, _, _, ok3 := p.Plan(nil, "c")
+	if !ok3 || idx3.Fields[0] != "c" {
+		t.Fatalf("Plan(nil, \"c\") = %v, %v, want the c index to match on ordering alone", idx3, ok3)
+	}
+}
+
+func TestPlannerNoMatch(t *testing.T) {
+	p := NewPlanner([]Index{{Fields: []string{"a"}}})
+	_, _, _, ok := p.Plan([]FieldFilter{
+		{Path: []string{"z"}, Filter: Equal, Value: Int(1)},
+	}, "")
+	if ok {
+		t.Fatal("Plan matched an index for a field it doesn't cover")
+	}
+}
+
+// scanDB implements RangeScanner so Scan can be tested against both its
+// native path and its Query fallback from the same fixture.
+type scanDB struct {
+	*memDB
+	lastIdx        Index
+	lastLow        []Value
+	lastHigh       []Value
+	rangeScanCalls int
+}
+
+func (db *scanDB) RangeScan(ctx context.Context, col string, idx Index, low, high []Value) DocIterator {
+	db.rangeScanCalls++
+	db.lastIdx, db.lastLow, db.lastHigh = idx, low, high
+	return db.Query(col).WithFields(FieldFilter{Path: idx.Fields[:1], Filter: GTE, Value: low[0]}).Iterate()
+}
+
+func TestScanUsesRangeScanWhenPlanMatches(t *testing.T) {
+	db := &scanDB{memDB: newMemDB()}
+	ctx := context.Background()
+	db.Insert(ctx, "docs", nil, Document{"n": Int(1)})
+	db.Insert(ctx, "docs", nil, Document{"n": Int(7)})
+
+	p := NewPlanner([]Index{{Fields: []string{"n"}}})
+	filters := []FieldFilter{{Path: []string{"n"}, Filter: GTE, Value: Int(5)}}
+
+	it := Scan(ctx, db, "docs", p, filters, "")
+	defer it.Close()
+
+	var n int
+	for it.Next(ctx) {
+		n++
+	}
+	if db.rangeScanCalls != 1 {
+		t.Fatalf("RangeScan called %d times, want 1", db.rangeScanCalls)
+	}
+	if n != 1 {
+		t.Fatalf("Scan returned %d docs, want 1", n)
+	}
+}
+
+func TestScanFallsBackToQueryWithoutRangeScanner(t *testing.T) {
+	db := newMemDB()
+	ctx := context.Background()
+	db.Insert(ctx, "docs", nil, Document{"n": Int(1)})
+	db.Insert(ctx, "docs", nil, Document{"n": Int(7)})
+
+	p := NewPlanner([]Index{{Fields: []string{"n"}}})
+	filters := []FieldFilter{{Path: []string{"n"}, Filter: GTE, Value: Int(5)}}
+
+	it := Scan(ctx, db, "docs", p, filters, "")
+	defer it.Close()
+
+	var n int
+	for it.Next(ctx) {
+		n++
+	}
+	if n != 1 {
+		t.Fatalf("Scan fallback returned %d docs, want 1", n)
+	}
+}
@@ -0,0 +1,87 @@
+package nosql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Options holds driver-specific connection options passed to Registration.Open.
+type Options map[string]interface{}
+
+// Registration describes a nosql driver that can be opened by the scheme of
+// its URI (via Open) or explicitly by name (via OpenNamed).
+type Registration struct {
+	// Name is the unique name of the driver, e.g. "mongodb" or "postgres".
+	// Open matches it against the scheme of a URI.
+	Name string
+	// Open connects to a database described by uri using the given options.
+	Open func(uri string, opts Options) (Database, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Registration)
+)
+
+// Register adds a driver to the global registry. It panics if a driver with the
+// same name is already registered, or on init-time misuse from multiple drivers
+// sharing a name.
+func Register(reg Registration) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[reg.Name]; ok {
+		panic(fmt.Sprintf("nosql: driver %q already registered", reg.Name))
+	}
+	registry[reg.Name] = reg
+}
+
+// Registered returns the names of all registered drivers, sorted.
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open opens a database using the driver registered under uri's scheme (the
+// part before "://"), passing it uri and opts. For example
+// Open("mongodb://localhost/db", nil) dispatches to the driver registered as
+// "mongodb". It returns an error if uri has no scheme or no driver was
+// registered under it.
+func Open(uri string, opts Options) (Database, error) {
+	scheme, err := uriScheme(uri)
+	if err != nil {
+		return nil, err
+	}
+	return OpenNamed(scheme, uri, opts)
+}
+
+// OpenNamed opens a database using the driver named name, passing it uri and
+// opts. Unlike Open, it dispatches by driver name directly instead of
+// parsing uri's scheme, which callers that already know which driver they
+// want (or whose URIs don't carry a scheme) can use instead.
+// It returns an error if no driver with that name was registered.
+func OpenNamed(name string, uri string, opts Options) (Database, error) {
+	registryMu.RLock()
+	reg, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("nosql: unknown driver %q", name)
+	}
+	return reg.Open(uri, opts)
+}
+
+// uriScheme extracts the scheme (the part before "://") from uri.
+func uriScheme(uri string) (string, error) {
+	i := strings.Index(uri, "://")
+	if i <= 0 {
+		return "", fmt.Errorf("nosql: uri %q has no scheme", uri)
+	}
+	return uri[:i], nil
+}
@@ -0,0 +1,34 @@
+package nosql
+
+import "testing"
+
+func TestOpenDispatchesByURIScheme(t *testing.T) {
+	const name = "fakescheme"
+	var gotURI string
+	Register(Registration{
+		Name: name,
+		Open: func(uri string, opts Options) (Database, error) {
+			gotURI = uri
+			return &fakeDB{}, nil
+		},
+	})
+
+	uri := name + "://localhost/db"
+	db, err := Open(uri, nil)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", uri, err)
+	}
+	if db == nil {
+		t.Fatal("Open returned a nil Database")
+	}
+	if gotURI != uri {
+		t.Fatalf("driver received uri %q, want %q", gotURI, uri)
+	}
+
+	if _, err := Open("nosuchscheme://host/db", nil); err == nil {
+		t.Fatal("expected Open to fail for an unregistered scheme")
+	}
+	if _, err := Open("no-scheme-at-all", nil); err == nil {
+		t.Fatal("expected Open to fail for a uri without a scheme")
+	}
+}
@@ -0,0 +1,82 @@
+package nosql
+
+import (
+	"context"
+	"testing"
+)
+
+func TestUpdateAllFallbackSetAndInc(t *testing.T) {
+	db := newMemDB()
+	ctx := context.Background()
+
+	k1, _ := db.Insert(ctx, "docs", Key{"a"}, Document{"status": String("old"), "hits": Int(1)})
+	k2, _ := db.Insert(ctx, "docs", Key{"b"}, Document{"status": String("old"), "hits": Int(2)})
+	if _, err := db.Insert(ctx, "docs", Key{"c"}, Document{"status": String("new"), "hits": Int(5)}); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := UpdateAll(db, "docs").
+		WithFields(FieldFilter{Path: []string{"status"}, Filter: Equal, Value: String("old")}).
+		Set("status", String("new")).
+		Inc("hits", 10).
+		Do(ctx)
+	if err != nil {
+		t.Fatalf("UpdateAll.Do: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("updated %d documents, want 2", n)
+	}
+
+	for _, tc := range []struct {
+		key      Key
+		wantHits Int
+	}{
+		{k1, 11},
+		{k2, 12},
+	} {
+		doc, err := db.FindByKey(ctx, "docs", tc.key)
+		if err != nil {
+			t.Fatalf("FindByKey(%v): %v", tc.key, err)
+		}
+		if doc["status"] != String("new") {
+			t.Fatalf("doc %v status = %v, want \"new\" (Set must apply unconditionally, not just on insert)", tc.key, doc["status"])
+		}
+		if doc["hits"] != tc.wantHits {
+			t.Fatalf("doc %v hits = %v, want %v", tc.key, doc["hits"], tc.wantHits)
+		}
+	}
+
+	unchanged, err := db.FindByKey(ctx, "docs", Key{"c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unchanged["hits"] != Int(5) {
+		t.Fatalf("non-matching doc was modified: hits = %v, want 5", unchanged["hits"])
+	}
+}
+
+func TestDeleteLimitFallback(t *testing.T) {
+	db := newMemDB()
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		if _, err := db.Insert(ctx, "docs", nil, Document{"kind": String("x")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	del := DeleteLimit(db, "docs", db.Delete("docs").WithFields(FieldFilter{
+		Path: []string{"kind"}, Filter: Equal, Value: String("x"),
+	}), 3)
+	if err := del.Do(ctx); err != nil {
+		t.Fatalf("DeleteLimit.Do: %v", err)
+	}
+
+	n, err := db.Query("docs").WithFields(FieldFilter{Path: []string{"kind"}, Filter: Equal, Value: String("x")}).Count(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("%d documents remain, want 2 (5 - limit of 3)", n)
+	}
+}
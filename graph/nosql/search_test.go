@@ -0,0 +1,45 @@
+package nosql
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSearchFallbackUsesCaseInsensitiveRegex(t *testing.T) {
+	db := newMemDB()
+	ctx := context.Background()
+
+	db.Insert(ctx, "docs", Key{"a"}, Document{"title": String("Graph Databases 101")})
+	db.Insert(ctx, "docs", Key{"b"}, Document{"title": String("Intro to SQL")})
+	db.Insert(ctx, "docs", Key{"c"}, Document{"title": String("Advanced graph theory")})
+
+	q := Search(db.Query("docs"), "title", "graph")
+	n, err := q.Count(ctx)
+	if err != nil {
+		t.Fatalf("Count: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("Search matched %d documents, want 2 (case-insensitive substring match)", n)
+	}
+
+	// FulltextSearcher is not implemented by memDB, so Search must have gone
+	// through the regex-scan fallback rather than a native Query.Search call.
+	if _, ok := db.Query("docs").(FulltextSearcher); ok {
+		t.Fatal("memDB unexpectedly implements FulltextSearcher; this test no longer exercises the fallback")
+	}
+}
+
+func TestSearchFallbackEscapesTerms(t *testing.T) {
+	db := newMemDB()
+	ctx := context.Background()
+	db.Insert(context.Background(), "docs", Key{"a"}, Document{"title": String("c++ programming")})
+	db.Insert(context.Background(), "docs", Key{"b"}, Document{"title": String("cXX programming")})
+
+	n, err := Search(db.Query("docs"), "title", "c++").Count(ctx)
+	if err != nil {
+		t.Fatalf("Count: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("Search for a literal \"c++\" matched %d documents, want 1 (terms must be regex-escaped)", n)
+	}
+}
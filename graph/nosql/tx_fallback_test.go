@@ -0,0 +1,102 @@
+package nosql
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTxCommitAppliesMutationsAtomically(t *testing.T) {
+	db := newMemDB()
+	ctx := context.Background()
+	k1, _ := db.Insert(ctx, "docs", Key{"a"}, Document{"hits": Int(1)})
+
+	tdb, ok := Database(db).(Transactional)
+	if !ok {
+		t.Fatal("memDB does not implement Transactional")
+	}
+	tx, err := tdb.Tx(ctx)
+	if err != nil {
+		t.Fatalf("Tx: %v", err)
+	}
+
+	if _, err := tx.Insert(ctx, "docs", Key{"b"}, Document{"hits": Int(2)}); err != nil {
+		t.Fatalf("tx.Insert: %v", err)
+	}
+	if err := tx.Update("docs", k1).Inc("hits", 10).Do(ctx); err != nil {
+		t.Fatalf("tx.Update: %v", err)
+	}
+
+	// Mutations must not be visible outside the transaction before Commit.
+	if doc, err := db.FindByKey(ctx, "docs", k1); err != nil || doc["hits"] != Int(1) {
+		t.Fatalf("uncommitted tx mutation leaked: hits = %v, %v", doc["hits"], err)
+	}
+	if _, err := db.FindByKey(ctx, "docs", Key{"b"}); err != ErrNotFound {
+		t.Fatalf("uncommitted tx insert leaked: err = %v", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	doc, err := db.FindByKey(ctx, "docs", k1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc["hits"] != Int(11) {
+		t.Fatalf("hits = %v, want 11 after commit", doc["hits"])
+	}
+	if _, err := db.FindByKey(ctx, "docs", Key{"b"}); err != nil {
+		t.Fatalf("FindByKey(b): %v, want inserted doc to be visible after commit", err)
+	}
+}
+
+func TestTxRollbackDiscardsMutations(t *testing.T) {
+	db := newMemDB()
+	ctx := context.Background()
+	k1, _ := db.Insert(ctx, "docs", Key{"a"}, Document{"hits": Int(1)})
+
+	tdb := Database(db).(Transactional)
+	tx, err := tdb.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Update("docs", k1).Inc("hits", 10).Do(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(ctx); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+
+	doc, err := db.FindByKey(ctx, "docs", k1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc["hits"] != Int(1) {
+		t.Fatalf("hits = %v, want 1 (rollback must discard the Inc)", doc["hits"])
+	}
+}
+
+func TestTxCommitConflict(t *testing.T) {
+	db := newMemDB()
+	ctx := context.Background()
+	k1, _ := db.Insert(ctx, "docs", Key{"a"}, Document{"hits": Int(1)})
+
+	tdb := Database(db).(Transactional)
+	tx, err := tdb.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Update("docs", k1).Inc("hits", 10).Do(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// A concurrent, non-transactional write to the same document should make
+	// Commit fail with ErrConflict instead of silently clobbering it.
+	if err := db.Update("docs", k1).Inc("hits", 100).Do(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tx.Commit(ctx); err != ErrConflict {
+		t.Fatalf("Commit returned %v, want ErrConflict", err)
+	}
+}
@@ -0,0 +1,157 @@
+package nosql
+
+import (
+	"context"
+	"reflect"
+)
+
+// memTx is a minimal optimistic-concurrency Tx over a memDB: it buffers
+// mutations and, on Commit, fails with ErrConflict if any document it
+// inserted, updated or read via FindByKey changed in the db since the
+// transaction first touched it. Documents removed via Delete are not tracked
+// for conflicts, and FindByKey and Query read the db directly, so they do
+// not see the transaction's own pending writes.
+type memTx struct {
+	db      *memDB
+	snap    map[string]map[string]Document // col -> key string -> doc as of first touch
+	pending []func()
+	done    bool
+}
+
+func (db *memDB) Tx(ctx context.Context) (Tx, error) {
+	return &memTx{db: db, snap: make(map[string]map[string]Document)}, nil
+}
+
+func keyStr(k Key) string {
+	s := ""
+	for _, p := range k {
+		s += p + "\x00"
+	}
+	return s
+}
+
+// track snapshots col/key's current document (nil if absent) the first time
+// the transaction touches it, so Commit can detect concurrent changes.
+func (tx *memTx) track(col string, key Key) {
+	if tx.snap[col] == nil {
+		tx.snap[col] = make(map[string]Document)
+	}
+	ks := keyStr(key)
+	if _, ok := tx.snap[col][ks]; ok {
+		return
+	}
+	doc, err := tx.db.FindByKey(context.Background(), col, key)
+	if err != nil {
+		doc = nil
+	}
+	tx.snap[col][ks] = doc
+}
+
+func (tx *memTx) Insert(ctx context.Context, col string, key Key, d Document) (Key, error) {
+	if key == nil {
+		key = GenKey()
+	}
+	tx.track(col, key)
+	d = cloneDoc(d)
+	tx.pending = append(tx.pending, func() {
+		tx.db.mu.Lock()
+		defer tx.db.mu.Unlock()
+		tx.db.cols[col] = append(tx.db.cols[col], memDoc{key: key, doc: d})
+	})
+	return key, nil
+}
+
+func (tx *memTx) Update(col string, key Key) Update {
+	tx.track(col, key)
+	return &txUpdate{tx: tx, u: tx.db.Update(col, key)}
+}
+
+func (tx *memTx) Delete(col string) Delete {
+	return &txDelete{tx: tx, d: tx.db.Delete(col)}
+}
+
+// txUpdate defers the wrapped Update's Do until Commit, so a transaction's
+// mutations only take effect atomically and are discarded by Rollback.
+type txUpdate struct {
+	tx *memTx
+	u  Update
+}
+
+func (u *txUpdate) Inc(field string, dn int) Update {
+	return &txUpdate{tx: u.tx, u: u.u.Inc(field, dn)}
+}
+
+func (u *txUpdate) Upsert(d Document) Update {
+	return &txUpdate{tx: u.tx, u: u.u.Upsert(d)}
+}
+
+func (u *txUpdate) Do(ctx context.Context) error {
+	u.tx.pending = append(u.tx.pending, func() { u.u.Do(ctx) })
+	return nil
+}
+
+// txDelete defers the wrapped Delete's Do until Commit, for the same reason
+// as txUpdate.
+type txDelete struct {
+	tx *memTx
+	d  Delete
+}
+
+func (d *txDelete) WithFields(filters ...FieldFilter) Delete {
+	return &txDelete{tx: d.tx, d: d.d.WithFields(filters...)}
+}
+
+func (d *txDelete) Keys(keys ...Key) Delete {
+	return &txDelete{tx: d.tx, d: d.d.Keys(keys...)}
+}
+
+func (d *txDelete) Do(ctx context.Context) error {
+	d.tx.pending = append(d.tx.pending, func() { d.d.Do(ctx) })
+	return nil
+}
+
+func (tx *memTx) FindByKey(ctx context.Context, col string, key Key) (Document, error) {
+	tx.track(col, key)
+	return tx.db.FindByKey(ctx, col, key)
+}
+
+func (tx *memTx) Query(col string) Query {
+	return tx.db.Query(col)
+}
+
+func (tx *memTx) Commit(ctx context.Context) error {
+	tx.db.mu.Lock()
+	conflict := false
+	for col, byKey := range tx.snap {
+		for ks, want := range byKey {
+			var got Document
+			for _, md := range tx.db.cols[col] {
+				if keyStr(md.key) == ks {
+					got = md.doc
+					break
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				conflict = true
+			}
+		}
+	}
+	tx.db.mu.Unlock()
+	if conflict {
+		return ErrConflict
+	}
+
+	// The pending ops (memDB.Insert/Update.Do/Delete.Do) each take db.mu
+	// themselves, so they must run after it's released above.
+	for _, op := range tx.pending {
+		op()
+	}
+	tx.done = true
+	return nil
+}
+
+func (tx *memTx) Rollback(ctx context.Context) error {
+	tx.pending = nil
+	tx.done = true
+	return nil
+}
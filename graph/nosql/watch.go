@@ -0,0 +1,50 @@
+package nosql
+
+import "context"
+
+// Watcher is an optional interface for databases that can stream changes made to
+// a collection, so callers can react to inserts/updates/deletes without polling.
+type Watcher interface {
+	// Watch starts streaming changes made to col after opts.Since/opts.Resume.
+	Watch(ctx context.Context, col string, opts WatchOptions) (ChangeIterator, error)
+}
+
+// WatchOptions configures where a change stream starts from.
+type WatchOptions struct {
+	// Resume resumes the stream right after the change that produced this token,
+	// as returned by Change.Resume. It takes precedence over Since if both are set.
+	Resume []byte
+	// Since, if true and Resume is unset, requests only changes made after Watch
+	// was called, skipping the collection's current contents.
+	Since bool
+}
+
+// ChangeOp identifies the kind of mutation a Change represents.
+type ChangeOp int
+
+const (
+	OpInsert = ChangeOp(iota)
+	OpUpdate
+	OpDelete
+)
+
+// Change describes a single mutation observed on a watched collection.
+type Change struct {
+	Op     ChangeOp
+	Key    Key
+	Before Document // document state before the change; nil for Insert
+	After  Document // document state after the change; nil for Delete
+	Resume []byte   // opaque token to resume the stream after this change via WatchOptions.Resume
+}
+
+// ChangeIterator is an iterator over a live stream of Change events.
+type ChangeIterator interface {
+	// Next blocks until the next change is available, ctx is done, or the stream ends.
+	Next(ctx context.Context) bool
+	// Err returns a last encountered error.
+	Err() error
+	// Close stops the stream and frees all associated resources.
+	Close() error
+	// Change returns the current change event.
+	Change() Change
+}
@@ -0,0 +1,94 @@
+package nosql
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWatchReplaysHistoryByDefault(t *testing.T) {
+	db := newWatchDB()
+	ctx := context.Background()
+
+	db.Insert(ctx, "docs", Key{"a"}, Document{"n": Int(1)})
+	db.Insert(ctx, "docs", Key{"b"}, Document{"n": Int(2)})
+	db.Insert(ctx, "other", Key{"c"}, Document{"n": Int(3)})
+
+	it, err := db.Watch(ctx, "docs", WatchOptions{})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	defer it.Close()
+
+	var got []Key
+	for it.Next(ctx) {
+		c := it.Change()
+		if c.Op != OpInsert {
+			t.Fatalf("Change.Op = %v, want OpInsert", c.Op)
+		}
+		got = append(got, c.Key)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d changes, want 2 (only the \"docs\" collection's inserts)", len(got))
+	}
+}
+
+func TestWatchSinceSkipsExistingHistory(t *testing.T) {
+	db := newWatchDB()
+	ctx := context.Background()
+	db.Insert(ctx, "docs", Key{"a"}, Document{"n": Int(1)})
+
+	it, err := db.Watch(ctx, "docs", WatchOptions{Since: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it.Close()
+
+	if it.Next(ctx) {
+		t.Fatalf("Since: true unexpectedly replayed pre-existing history: %v", it.Change())
+	}
+
+	db.Insert(ctx, "docs", Key{"b"}, Document{"n": Int(2)})
+	it2, err := db.Watch(ctx, "docs", WatchOptions{Since: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it2.Close()
+	if it2.Next(ctx) {
+		t.Fatal("a second Since: true Watch must only see changes made after it starts, not b's insert from before it")
+	}
+}
+
+func TestWatchResumeContinuesAfterToken(t *testing.T) {
+	db := newWatchDB()
+	ctx := context.Background()
+	db.Insert(ctx, "docs", Key{"a"}, Document{"n": Int(1)})
+	db.Insert(ctx, "docs", Key{"b"}, Document{"n": Int(2)})
+	db.Insert(ctx, "docs", Key{"c"}, Document{"n": Int(3)})
+
+	it, err := db.Watch(ctx, "docs", WatchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !it.Next(ctx) {
+		t.Fatal("expected at least one change")
+	}
+	resume := it.Change().Resume
+	it.Close()
+
+	it2, err := db.Watch(ctx, "docs", WatchOptions{Resume: resume})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it2.Close()
+
+	var got []Key
+	for it2.Next(ctx) {
+		got = append(got, it2.Change().Key)
+	}
+	if len(got) != 2 || string(got[0][0]) != "b" || string(got[1][0]) != "c" {
+		t.Fatalf("resumed changes = %v, want [b, c]", got)
+	}
+}
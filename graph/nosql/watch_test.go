@@ -0,0 +1,85 @@
+package nosql
+
+import (
+	"context"
+	"sync"
+)
+
+// watchDB extends memDB with a minimal Watcher implementation: every Insert
+// is recorded as a Change, and Watch replays that history (optionally
+// resuming mid-stream or skipping straight to the end), since memDB has no
+// natural "native" change feed to fall back from.
+type watchDB struct {
+	*memDB
+
+	mu   sync.Mutex
+	hist []chRecord
+}
+
+type chRecord struct {
+	col string
+	c   Change
+}
+
+func newWatchDB() *watchDB {
+	return &watchDB{memDB: newMemDB()}
+}
+
+func (db *watchDB) Insert(ctx context.Context, col string, key Key, d Document) (Key, error) {
+	key, err := db.memDB.Insert(ctx, col, key, d)
+	if err != nil {
+		return key, err
+	}
+	db.mu.Lock()
+	db.hist = append(db.hist, chRecord{col: col, c: Change{
+		Op:     OpInsert,
+		Key:    key,
+		After:  cloneDoc(d),
+		Resume: encodeOffset(len(db.hist) + 1),
+	}})
+	db.mu.Unlock()
+	return key, nil
+}
+
+func (db *watchDB) Watch(ctx context.Context, col string, opts WatchOptions) (ChangeIterator, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	start := 0
+	switch {
+	case len(opts.Resume) > 0:
+		start = decodeOffset(opts.Resume)
+	case opts.Since:
+		start = len(db.hist)
+	}
+	if start > len(db.hist) {
+		start = len(db.hist)
+	}
+
+	var changes []Change
+	for _, rec := range db.hist[start:] {
+		if rec.col == col {
+			changes = append(changes, rec.c)
+		}
+	}
+	return &watchIter{changes: changes, pos: -1}, nil
+}
+
+// watchIter replays a fixed slice of changes recorded by watchDB.Watch. It
+// doesn't block for new changes, since tests only need to observe history
+// already recorded by the time Watch is called.
+type watchIter struct {
+	changes []Change
+	pos     int
+}
+
+func (it *watchIter) Next(ctx context.Context) bool {
+	it.pos++
+	return it.pos < len(it.changes)
+}
+
+func (it *watchIter) Err() error   { return nil }
+func (it *watchIter) Close() error { return nil }
+func (it *watchIter) Change() Change {
+	return it.changes[it.pos]
+}